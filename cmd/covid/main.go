@@ -0,0 +1,393 @@
+// Command covid shows the number of COVID-19 cases, sourced from the
+// CSSE COVID-19 time series, and can optionally serve that data over
+// HTTP.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/gregjones/httpcache"
+	"github.com/gregjones/httpcache/diskcache"
+	"github.com/guptarohit/asciigraph"
+	"github.com/spf13/pflag"
+	"golang.org/x/text/message"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+	"github.com/m4ns0ur/covid/internal/logging"
+	"github.com/m4ns0ur/covid/internal/source"
+)
+
+var (
+	p = message.NewPrinter(message.MatchLanguage("en"))
+	w = new(tabwriter.Writer)
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServe(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "covid serve: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := pflag.NewFlagSet("covid", pflag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stdout, `Shows number of COVID-19 cases.
+
+Usage:
+  covid [flags]
+  covid serve [flags]
+
+Flags:
+  -e, --cache              enable request caching (default true)
+  -c, --country string     country to show number of cases for
+      --doubling           show estimated doubling time for the selected country
+  -g  --graph              plot graph, only if country is selected; overlays a
+                           smoothed series when --rolling is set
+      --log-format string  log output format: text or json (default "text")
+      --log-level string   log level: debug, info, warn or error (default "info")
+      --offline            fail fast instead of fetching, using only locally cached data
+      --per-100k           show cases per 100,000 population for the selected country;
+                           covers a fixed set of countries (see internal/dataset/population.csv
+                           or ~/covid/population.csv), reports "no population data" otherwise
+      --rolling int        rolling average window in days for --doubling and --top-growth
+                           (default 7 if unset); also overlays a smoothed series on --graph,
+                           which is off by default (default 0)
+  -s, --save                save/overwrite data in file (default true)
+      --source string      data source: github-contents, github-raw, local or http (default "github-contents")
+      --source-url string  base URL or directory for --source=http or --source=local
+  -t, --top-confirmed      Top 10 countries by most confirmed cases
+      --top-dead           Top 10 countries by most dead cases
+      --top-growth         Top 10 countries by average daily growth in confirmed cases over --rolling days
+      --top-recovered      Top 10 countries by most recovered cases
+  -v, --verbose            more verbose operation information, shorthand for --log-level=debug
+  -h, --help               help for covid
+
+Run "covid serve -h" for the HTTP API subcommand.
+`)
+	}
+
+	var (
+		fcache, fsave, ftopc, ftopd, ftopr, ftopgrowth, fgraph, fdoubling, fper100k, foffline, fverbose, fhelp bool
+		fcountry, flogLevel, flogFormat, fsource, fsourceURL                                                   string
+		frolling                                                                                               int
+	)
+
+	fs.BoolVarP(&fcache, "cache", "e", true, "enable request caching")
+	fs.BoolVarP(&fsave, "save", "s", true, "save/overwrite data in file")
+	fs.BoolVarP(&ftopc, "top-confirmed", "t", false, "Top 10 countries by most confirmed cases")
+	fs.BoolVarP(&ftopd, "top-dead", "", false, "Top 10 countries by most dead cases")
+	fs.BoolVarP(&ftopr, "top-recovered", "", false, "Top 10 countries by most recovered cases")
+	fs.BoolVar(&ftopgrowth, "top-growth", false, "Top 10 countries by average daily growth in confirmed cases over --rolling days")
+	fs.StringVarP(&fcountry, "country", "c", "", "country to show number of cases for")
+	fs.BoolVarP(&fgraph, "graph", "g", false, "plot graph, only if country is selected")
+	fs.IntVar(&frolling, "rolling", 0, "rolling average window in days for --doubling/--top-growth (default 7 if unset); also overlays a smoothed series on --graph when positive")
+	fs.BoolVar(&fdoubling, "doubling", false, "show estimated doubling time for the selected country")
+	fs.BoolVar(&fper100k, "per-100k", false, "show cases per 100,000 population for the selected country")
+	fs.StringVar(&fsource, "source", "github-contents", "data source: github-contents, github-raw, local or http")
+	fs.StringVar(&fsourceURL, "source-url", "", "base URL or directory for --source=http or --source=local")
+	fs.BoolVar(&foffline, "offline", false, "fail fast instead of fetching, using only locally cached data")
+	fs.BoolVarP(&fverbose, "verbose", "v", false, "more verbose operation information, shorthand for --log-level=debug")
+	fs.StringVarP(&flogLevel, "log-level", "", "info", "log level: debug, info, warn or error")
+	fs.StringVarP(&flogFormat, "log-format", "", "text", "log output format: text or json")
+	fs.BoolVarP(&fhelp, "help", "h", false, "help for covid")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fhelp {
+		fs.Usage()
+		return nil
+	}
+
+	if fverbose {
+		flogLevel = "debug"
+	}
+	if !logging.ValidLevel(flogLevel) {
+		return fmt.Errorf("invalid --log-level %q: must be one of debug, info, warn, error", flogLevel)
+	}
+	if !logging.ValidFormat(flogFormat) {
+		return fmt.Errorf("invalid --log-format %q: must be one of text, json", flogFormat)
+	}
+	logger := logging.New(flogLevel, flogFormat)
+
+	wd, err := workingDir(logger)
+	if err != nil {
+		return err
+	}
+
+	src, err := newSource(fsource, fsourceURL, wd, foffline, httpClient(fcache, wd))
+	if err != nil {
+		return err
+	}
+
+	sets, err := fetchAll(context.Background(), src, wd, fsave, logger)
+	if err != nil {
+		return err
+	}
+	conf, dd, recov := sets[dataset.Confirmed], sets[dataset.Dead], sets[dataset.Recovered]
+
+	var (
+		bold   = color.New(color.Bold).SprintFunc()
+		green  = color.New(color.FgGreen).SprintFunc()
+		yellow = color.New(color.FgYellow).SprintFunc()
+		red    = color.New(color.FgRed).SprintFunc()
+	)
+
+	fmt.Printf("%v\n", bold("Globe"))
+	w.Init(os.Stdout, 0, 0, 0, ' ', 0)
+	printCases(conf, "Confirmed", yellow)
+	printCases(dd, "Dead", red)
+	printCases(recov, "Recovered", green)
+	w.Flush()
+
+	if fcountry != "" {
+		cconf, found := conf.Filter(fcountry)
+		if !found {
+			return fmt.Errorf("country %v is not in the list", fcountry)
+		}
+		cdead, found := dd.Filter(fcountry)
+		if !found {
+			return fmt.Errorf("country %v is not in the list", fcountry)
+		}
+		crecov, found := recov.Filter(fcountry)
+		if !found {
+			return fmt.Errorf("country %v is not in the list", fcountry)
+		}
+
+		fmt.Printf("\n%v\n", bold(cconf.Country))
+		w.Init(os.Stdout, 0, 0, 0, ' ', 0)
+		printRecordCases(cconf, "Confirmed", yellow)
+		printRecordCases(cdead, "Dead", red)
+		printRecordCases(crecov, "Recovered", green)
+
+		if fdoubling {
+			printDoubling(cconf, "Confirmed", rollingWindow(frolling))
+			printDoubling(cdead, "Dead", rollingWindow(frolling))
+			printDoubling(crecov, "Recovered", rollingWindow(frolling))
+		}
+
+		if fper100k {
+			pop, err := dataset.LoadPopulation(filepath.Join(wd, "population.csv"))
+			if err != nil {
+				return err
+			}
+			printPerCapita(cconf, "Confirmed", pop)
+			printPerCapita(cdead, "Dead", pop)
+			printPerCapita(crecov, "Recovered", pop)
+		}
+		w.Flush()
+
+		if fgraph {
+			printGraph(cconf, "Confirmed", yellow, frolling)
+			printGraph(cdead, "Dead", red, frolling)
+			printGraph(crecov, "Recovered", green, frolling)
+		}
+	}
+
+	if ftopc {
+		printTop(conf, "confirmed", yellow)
+	}
+	if ftopd {
+		printTop(dd, "dead", red)
+	}
+	if ftopr {
+		printTop(recov, "recovered", green)
+	}
+	if ftopgrowth {
+		printTopGrowth(conf, "confirmed", yellow, rollingWindow(frolling))
+	}
+
+	return nil
+}
+
+// defaultRollingWindow is the window used by --doubling and
+// --top-growth when --rolling isn't explicitly set.
+const defaultRollingWindow = 7
+
+// rollingWindow returns window, or defaultRollingWindow if window
+// isn't positive. --graph, unlike --doubling/--top-growth, does not
+// use this fallback: its smoothed overlay only appears when the user
+// explicitly passes a positive --rolling.
+func rollingWindow(window int) int {
+	if window <= 0 {
+		return defaultRollingWindow
+	}
+	return window
+}
+
+func workingDir(logger *logging.Logger) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		logger.Warn("could not get the user home dir", "err", err)
+		return "", nil
+	}
+	wd := filepath.Join(home, "covid")
+	if err := os.MkdirAll(filepath.Dir(wd), 0755); err != nil {
+		logger.Warn("could not create working dir", "dir", wd, "err", err)
+	}
+	return wd, nil
+}
+
+func httpClient(cache bool, wd string) *http.Client {
+	if !cache {
+		return nil
+	}
+	return httpcache.NewTransport(diskcache.New(filepath.Join(wd, "cache"))).Client()
+}
+
+// fetchAll fetches and decodes all three CSSE time series
+// concurrently, returning them indexed by dataset.Kind.
+func fetchAll(ctx context.Context, src source.Source, wd string, save bool, logger *logging.Logger) ([3]dataset.Dataset, error) {
+	var (
+		sets [3]dataset.Dataset
+		errs [3]error
+		wg   sync.WaitGroup
+	)
+
+	for _, kind := range dataset.Kinds {
+		wg.Add(1)
+		go func(kind dataset.Kind) {
+			defer wg.Done()
+			sets[kind], errs[kind] = fetchOne(ctx, src, wd, kind, save, logger)
+		}(kind)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return sets, err
+		}
+	}
+	return sets, nil
+}
+
+func fetchOne(ctx context.Context, src source.Source, wd string, kind dataset.Kind, save bool, logger *logging.Logger) (dataset.Dataset, error) {
+	logger.Debug("get remote data", "kind", kind, "path", kind.Filename())
+	raw, err := src.Fetch(ctx, kind)
+	if err != nil {
+		logger.Error("cannot get data", "kind", kind, "err", err)
+		return dataset.Dataset{}, err
+	}
+
+	if save && wd != "" {
+		if err := os.WriteFile(filepath.Join(wd, kind.Filename()), raw, 0644); err != nil {
+			logger.Error("cannot save data", "kind", kind, "err", err)
+			return dataset.Dataset{}, err
+		}
+	}
+
+	logger.Debug("convert data", "kind", kind)
+	ds, err := dataset.Decode(raw)
+	if err != nil {
+		logger.Error("cannot decode data", "kind", kind, "err", err)
+		return dataset.Dataset{}, err
+	}
+	return ds, nil
+}
+
+// The print* helpers below are pure formatting over data that fetchAll
+// and fetchOne have already fetched, decoded and logged; they have no
+// I/O or failure path of their own, so unlike those functions they
+// take no *logging.Logger.
+func printCases(d dataset.Dataset, t string, c func(a ...interface{}) string) {
+	s := d.Sum(-1)
+	n := s - d.Sum(-2)
+	fmt.Fprintf(w, "%v: \t%v \tNew: %v\n", t, c(p.Sprint(s)), c(p.Sprint(n)))
+}
+
+func printRecordCases(r dataset.Record, t string, c func(a ...interface{}) string) {
+	s := r.Cases[len(r.Cases)-1]
+	n := s - r.Cases[len(r.Cases)-2]
+	fmt.Fprintf(w, "%v: \t%v \tNew: %v\n", t, c(p.Sprint(s)), c(p.Sprint(n)))
+}
+
+func printDoubling(r dataset.Record, t string, window int) {
+	dt := r.DoublingTime(window)
+	switch {
+	case math.IsInf(dt, 1):
+		fmt.Fprintf(w, "%v doubling time: \tflat or declining\n", t)
+	default:
+		fmt.Fprintf(w, "%v doubling time: \t%.1f days\n", t, dt)
+	}
+}
+
+func printPerCapita(r dataset.Record, t string, pop dataset.Population) {
+	n, found := pop.Lookup(r.Country)
+	if !found {
+		fmt.Fprintf(w, "%v per 100k: \tno population data\n", t)
+		return
+	}
+	pc := r.PerCapita(n)
+	fmt.Fprintf(w, "%v per 100k: \t%.1f\n", t, pc[len(pc)-1])
+}
+
+func printGraph(r dataset.Record, t string, c func(a ...interface{}) string, window int) {
+	fmt.Println()
+	if window > 0 {
+		new := r.DailyNew()
+		raw := make([]float64, len(new))
+		for i, n := range new {
+			raw[i] = float64(n)
+		}
+		smoothed := r.SmoothedNew(window)
+		caption := fmt.Sprintf("%v - %v (daily new, %vd avg)", r.Country, t, window)
+		fmt.Println(c(asciigraph.PlotMany([][]float64{raw, smoothed}, asciigraph.Caption(caption), asciigraph.Width(70), asciigraph.Height(20))))
+		return
+	}
+
+	var ff []float64
+	for _, n := range r.Cases {
+		ff = append(ff, float64(n))
+	}
+	fmt.Println(c(asciigraph.Plot(ff, asciigraph.Caption(r.Country+" - "+t), asciigraph.Width(70), asciigraph.Height(20))))
+}
+
+func printTop(d dataset.Dataset, kind string, c func(a ...interface{}) string) {
+	r := d.Reduce()
+	r.Sort()
+	fmt.Printf("\n%v\n", color.New(color.Bold).Sprintf("Top 10 countries by most %v cases", kind))
+	w.Init(os.Stdout, 20, 0, 0, '.', 0)
+	n := 10
+	if n > len(r.Records) {
+		n = len(r.Records)
+	}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(w, "%2v-%v\t%v\n", i+1, r.Records[i].Country, c(p.Sprint(r.Records[i].Cases[len(r.Records[i].Cases)-1])))
+	}
+	w.Flush()
+}
+
+func printTopGrowth(d dataset.Dataset, kind string, c func(a ...interface{}) string, window int) {
+	r := d.Reduce()
+	r.SortByGrowth(window)
+	fmt.Printf("\n%v\n", color.New(color.Bold).Sprintf("Top 10 countries by average daily growth in %v cases (%vd)", kind, window))
+	w.Init(os.Stdout, 20, 0, 0, '.', 0)
+	n := 10
+	if n > len(r.Records) {
+		n = len(r.Records)
+	}
+	for i := 0; i < n; i++ {
+		growth := strconv.FormatFloat(r.Records[i].AverageDailyGrowth(window), 'f', 1, 64)
+		fmt.Fprintf(w, "%2v-%v\t%v\n", i+1, r.Records[i].Country, c(growth))
+	}
+	w.Flush()
+}
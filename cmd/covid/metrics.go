@@ -0,0 +1,53 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+// metrics holds the Prometheus gauges kept in sync with the Store on
+// every refresh.
+type metrics struct {
+	confirmedTotal *prometheus.GaugeVec
+	newCases       *prometheus.GaugeVec
+	lastUpdate     prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		confirmedTotal: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "covid_confirmed_total",
+			Help: "Total confirmed cases by country.",
+		}, []string{"country"}),
+		newCases: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "covid_new_cases",
+			Help: "New cases on the latest reported day, by country and kind.",
+		}, []string{"country", "kind"}),
+		lastUpdate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "covid_last_update_timestamp_seconds",
+			Help: "Unix timestamp of the last successful dataset refresh.",
+		}),
+	}
+	prometheus.MustRegister(m.confirmedTotal, m.newCases, m.lastUpdate)
+	return m
+}
+
+// observe updates all gauges from the current contents of store.
+func (m *metrics) observe(store *dataset.Store, at time.Time) {
+	for _, r := range store.Top(dataset.Confirmed, math.MaxInt32) {
+		total := r.Cases[len(r.Cases)-1]
+		m.confirmedTotal.WithLabelValues(r.Country).Set(float64(total))
+		m.newCases.WithLabelValues(r.Country, dataset.Confirmed.String()).Set(float64(total - r.Cases[len(r.Cases)-2]))
+	}
+	for _, kind := range []dataset.Kind{dataset.Dead, dataset.Recovered} {
+		for _, r := range store.Top(kind, math.MaxInt32) {
+			total := r.Cases[len(r.Cases)-1]
+			m.newCases.WithLabelValues(r.Country, kind.String()).Set(float64(total - r.Cases[len(r.Cases)-2]))
+		}
+	}
+	m.lastUpdate.Set(float64(at.Unix()))
+}
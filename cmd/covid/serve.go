@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+	"github.com/m4ns0ur/covid/internal/logging"
+)
+
+// runServe runs the "covid serve" subcommand: it periodically
+// refreshes the CSSE dataset and exposes it as a JSON HTTP API and
+// Prometheus metrics, until interrupted.
+func runServe(args []string) error {
+	fs := pflag.NewFlagSet("covid serve", pflag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stdout, `Serves COVID-19 data over HTTP.
+
+Usage:
+  covid serve [flags]
+
+Flags:
+  -a, --addr string        address to listen on (default ":8080")
+  -e, --cache              enable request caching (default true)
+      --log-format string  log output format: text or json (default "text")
+      --log-level string   log level: debug, info, warn or error (default "info")
+      --offline            fail fast instead of fetching, using only locally cached data
+      --refresh duration   how often to refresh data from the CSSE repo (default 15m0s)
+  -s, --save               save/overwrite data in file (default true)
+      --source string      data source: github-contents, github-raw, local or http (default "github-contents")
+      --source-url string  base URL or directory for --source=http or --source=local
+  -h, --help               help for covid serve
+`)
+	}
+
+	var (
+		faddr                   string
+		fcache, fsave, foffline bool
+		frefresh                time.Duration
+		flogLevel, flogFormat   string
+		fsource, fsourceURL     string
+		fhelp                   bool
+	)
+
+	fs.StringVarP(&faddr, "addr", "a", ":8080", "address to listen on")
+	fs.BoolVarP(&fcache, "cache", "e", true, "enable request caching")
+	fs.BoolVarP(&fsave, "save", "s", true, "save/overwrite data in file")
+	fs.DurationVar(&frefresh, "refresh", 15*time.Minute, "how often to refresh data from the CSSE repo")
+	fs.StringVar(&flogLevel, "log-level", "info", "log level: debug, info, warn or error")
+	fs.StringVar(&flogFormat, "log-format", "text", "log output format: text or json")
+	fs.StringVar(&fsource, "source", "github-contents", "data source: github-contents, github-raw, local or http")
+	fs.StringVar(&fsourceURL, "source-url", "", "base URL or directory for --source=http or --source=local")
+	fs.BoolVar(&foffline, "offline", false, "fail fast instead of fetching, using only locally cached data")
+	fs.BoolVarP(&fhelp, "help", "h", false, "help for covid serve")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fhelp {
+		fs.Usage()
+		return nil
+	}
+
+	if !logging.ValidLevel(flogLevel) {
+		return fmt.Errorf("invalid --log-level %q: must be one of debug, info, warn, error", flogLevel)
+	}
+	if !logging.ValidFormat(flogFormat) {
+		return fmt.Errorf("invalid --log-format %q: must be one of text, json", flogFormat)
+	}
+	logger := logging.New(flogLevel, flogFormat)
+
+	wd, err := workingDir(logger)
+	if err != nil {
+		return err
+	}
+
+	src, err := newSource(fsource, fsourceURL, wd, foffline, httpClient(fcache, wd))
+	if err != nil {
+		return err
+	}
+	store := dataset.NewStore()
+	met := newMetrics()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	refresh := func() error {
+		sets, err := fetchAll(ctx, src, wd, fsave, logger)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		store.Update(sets[dataset.Confirmed], sets[dataset.Dead], sets[dataset.Recovered], now)
+		met.observe(store, now)
+		logger.Info("refreshed dataset", "time", now)
+		return nil
+	}
+
+	if err := refresh(); err != nil {
+		return fmt.Errorf("initial refresh failed: %w", err)
+	}
+
+	go func() {
+		t := time.NewTicker(frefresh)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := refresh(); err != nil {
+					logger.Error("refresh failed", "err", err)
+				}
+			}
+		}
+	}()
+
+	srv := &http.Server{Addr: faddr, Handler: newRouter(store)}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("serving", "addr", faddr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-sigCh:
+		logger.Info("shutting down")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+func newRouter(store *dataset.Store) http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/global", handleGlobal(store)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/countries/{name}", handleCountry(store)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/top", handleTop(store)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/series/{country}", handleSeries(store)).Methods(http.MethodGet)
+	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+	return r
+}
+
+func handleGlobal(store *dataset.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		writeJSON(rw, http.StatusOK, store.Global())
+	}
+}
+
+func handleCountry(store *dataset.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		name := mux.Vars(req)["name"]
+		c, found := store.Country(name)
+		if !found {
+			http.Error(rw, fmt.Sprintf("country %q not found", name), http.StatusNotFound)
+			return
+		}
+		writeJSON(rw, http.StatusOK, c)
+	}
+}
+
+func handleTop(store *dataset.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		kind, err := parseKind(req.URL.Query().Get("kind"))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit := 25
+		if l := req.URL.Query().Get("limit"); l != "" {
+			n, err := parseLimit(l)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		writeJSON(rw, http.StatusOK, store.Top(kind, limit))
+	}
+}
+
+func handleSeries(store *dataset.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		country := mux.Vars(req)["country"]
+		kind, err := parseKind(req.URL.Query().Get("kind"))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		series, found := store.Series(country, kind)
+		if !found {
+			http.Error(rw, fmt.Sprintf("country %q not found", country), http.StatusNotFound)
+			return
+		}
+		writeJSON(rw, http.StatusOK, map[string]any{
+			"country": country,
+			"kind":    kind.String(),
+			"series":  series,
+		})
+	}
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(v)
+}
+
+func parseKind(s string) (dataset.Kind, error) {
+	switch s {
+	case "", "confirmed":
+		return dataset.Confirmed, nil
+	case "dead", "deaths":
+		return dataset.Dead, nil
+	case "recovered":
+		return dataset.Recovered, nil
+	default:
+		return 0, fmt.Errorf("invalid kind %q: must be one of confirmed, deaths, recovered", s)
+	}
+}
+
+func parseLimit(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid limit %q: must be a positive integer", s)
+	}
+	if n > math.MaxInt32 {
+		n = math.MaxInt32
+	}
+	return n, nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+func testStore() *dataset.Store {
+	s := dataset.NewStore()
+	confirmed := dataset.Dataset{Records: []dataset.Record{
+		{Country: "Testlandia", Cases: []int{10, 20, 30}},
+	}}
+	dead := dataset.Dataset{Records: []dataset.Record{
+		{Country: "Testlandia", Cases: []int{1, 2, 3}},
+	}}
+	recovered := dataset.Dataset{Records: []dataset.Record{
+		{Country: "Testlandia", Cases: []int{5, 10, 15}},
+	}}
+	s.Update(confirmed, dead, recovered, time.Now())
+	return s
+}
+
+func doRequest(t *testing.T, h http.Handler, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestHandleGlobal(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/global")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rw.Code, http.StatusOK)
+	}
+
+	var got dataset.Global
+	if err := json.Unmarshal(rw.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := dataset.Global{Confirmed: 30, NewConfirmed: 10, Dead: 3, NewDead: 1, Recovered: 15, NewRecovered: 5}
+	if got != want {
+		t.Errorf("body = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleCountry(t *testing.T) {
+	r := newRouter(testStore())
+
+	rw := doRequest(t, r, http.MethodGet, "/v1/countries/testlandia")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rw.Code, http.StatusOK)
+	}
+	var got dataset.CountrySummary
+	if err := json.Unmarshal(rw.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := dataset.CountrySummary{Country: "Testlandia", Confirmed: 30, Dead: 3, Recovered: 15}
+	if got != want {
+		t.Errorf("body = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleCountryNotFound(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/countries/nowhereistan")
+	if rw.Code != http.StatusNotFound {
+		t.Errorf("status = %v, want %v", rw.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleTop(t *testing.T) {
+	r := newRouter(testStore())
+
+	rw := doRequest(t, r, http.MethodGet, "/v1/top?kind=confirmed&limit=1")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rw.Code, http.StatusOK)
+	}
+	var got []dataset.Record
+	if err := json.Unmarshal(rw.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].Country != "Testlandia" {
+		t.Errorf("body = %+v, want one record for Testlandia", got)
+	}
+}
+
+func TestHandleTopBadKind(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/top?kind=bogus")
+	if rw.Code != http.StatusBadRequest {
+		t.Errorf("status = %v, want %v", rw.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleTopBadLimit(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/top?limit=0")
+	if rw.Code != http.StatusBadRequest {
+		t.Errorf("status = %v, want %v", rw.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSeries(t *testing.T) {
+	r := newRouter(testStore())
+
+	rw := doRequest(t, r, http.MethodGet, "/v1/series/Testlandia?kind=confirmed")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rw.Code, http.StatusOK)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(rw.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got["country"] != "Testlandia" || got["kind"] != "confirmed" {
+		t.Errorf("body = %+v, want country=Testlandia kind=confirmed", got)
+	}
+}
+
+func TestHandleSeriesNotFound(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/series/Nowhereistan")
+	if rw.Code != http.StatusNotFound {
+		t.Errorf("status = %v, want %v", rw.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleSeriesBadKind(t *testing.T) {
+	r := newRouter(testStore())
+	rw := doRequest(t, r, http.MethodGet, "/v1/series/Testlandia?kind=bogus")
+	if rw.Code != http.StatusBadRequest {
+		t.Errorf("status = %v, want %v", rw.Code, http.StatusBadRequest)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	if _, err := parseLimit("0"); err == nil {
+		t.Error("parseLimit(\"0\"): want error, got nil")
+	}
+	if _, err := parseLimit("-1"); err == nil {
+		t.Error("parseLimit(\"-1\"): want error, got nil")
+	}
+	if _, err := parseLimit("abc"); err == nil {
+		t.Error("parseLimit(\"abc\"): want error, got nil")
+	}
+	n, err := parseLimit("25")
+	if err != nil || n != 25 {
+		t.Errorf("parseLimit(\"25\") = %v, %v, want 25, nil", n, err)
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]dataset.Kind{
+		"":          dataset.Confirmed,
+		"confirmed": dataset.Confirmed,
+		"dead":      dataset.Dead,
+		"deaths":    dataset.Dead,
+		"recovered": dataset.Recovered,
+	}
+	for in, want := range tests {
+		got, err := parseKind(in)
+		if err != nil {
+			t.Errorf("parseKind(%q): unexpected error %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseKind(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := parseKind("bogus"); err == nil {
+		t.Error("parseKind(\"bogus\"): want error, got nil")
+	}
+}
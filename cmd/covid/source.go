@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/m4ns0ur/covid/internal/source"
+	"github.com/m4ns0ur/covid/internal/source/csse"
+)
+
+// newSource builds the Source named by name, pointed at url where
+// applicable. wd is used as the local data directory for "local" and
+// as the fallback cache location for the github sources. If offline
+// is set, name/url are ignored and a LocalDirSource over wd is
+// returned, so a missing cache fails fast instead of reaching out to
+// the network.
+func newSource(name, url, wd string, offline bool, httpClient *http.Client) (source.Source, error) {
+	if offline {
+		if url == "" {
+			url = wd
+		}
+		return source.NewLocalDirSource(url), nil
+	}
+
+	switch name {
+	case "", "github-contents":
+		return csse.NewGithubContentsSource(httpClient), nil
+	case "github-raw":
+		return csse.NewRawGithubSource(httpClient), nil
+	case "local":
+		if url == "" {
+			url = wd
+		}
+		return source.NewLocalDirSource(url), nil
+	case "http":
+		if url == "" {
+			return nil, fmt.Errorf("--source=http requires --source-url")
+		}
+		return source.NewHTTPSource(httpClient, url), nil
+	default:
+		return nil, fmt.Errorf("invalid --source %q: must be one of github-contents, github-raw, local, http", name)
+	}
+}
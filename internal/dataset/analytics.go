@@ -0,0 +1,98 @@
+package dataset
+
+import (
+	"math"
+	"sort"
+)
+
+// DailyNew returns the day-over-day new-case count for each day in
+// the record's cumulative Cases series. The first day's value is its
+// own cumulative count, since there is no prior day to diff against.
+func (r Record) DailyNew() []int {
+	new := make([]int, len(r.Cases))
+	for i, c := range r.Cases {
+		if i == 0 {
+			new[i] = c
+			continue
+		}
+		new[i] = c - r.Cases[i-1]
+	}
+	return new
+}
+
+// SmoothedNew returns the trailing rolling average of DailyNew over
+// the given window, e.g. window=7 for a 7-day average. Days before
+// the first full window average over however many days are
+// available.
+func (r Record) SmoothedNew(window int) []float64 {
+	if window <= 0 {
+		window = 1
+	}
+	new := r.DailyNew()
+	out := make([]float64, len(new))
+	sum := 0
+	for i, n := range new {
+		sum += n
+		if i >= window {
+			sum -= new[i-window]
+		}
+		days := window
+		if i+1 < days {
+			days = i + 1
+		}
+		out[i] = float64(sum) / float64(days)
+	}
+	return out
+}
+
+// DoublingTime estimates the number of days it takes for cumulative
+// cases to double, based on growth over the last window days:
+// t*ln(2)/ln(x_t/x_(t-window)). It returns +Inf if cases are flat or
+// falling, and 0 if there isn't window days of history.
+func (r Record) DoublingTime(window int) float64 {
+	n := len(r.Cases)
+	if window <= 0 || window >= n {
+		return 0
+	}
+
+	xt := float64(r.Cases[n-1])
+	x0 := float64(r.Cases[n-1-window])
+	if x0 <= 0 || xt <= x0 {
+		return math.Inf(1)
+	}
+	return float64(window) * math.Ln2 / math.Log(xt/x0)
+}
+
+// PerCapita returns Cases normalized to cases per 100,000 population.
+// It returns nil if population is not positive.
+func (r Record) PerCapita(population int) []float64 {
+	if population <= 0 {
+		return nil
+	}
+	out := make([]float64, len(r.Cases))
+	for i, c := range r.Cases {
+		out[i] = float64(c) / float64(population) * 100000
+	}
+	return out
+}
+
+// AverageDailyGrowth returns the average number of new cases per day
+// over the last window days: (x_t - x_(t-window)) / window.
+func (r Record) AverageDailyGrowth(window int) float64 {
+	n := len(r.Cases)
+	if window <= 0 || window >= n {
+		window = n - 1
+	}
+	if window <= 0 {
+		return 0
+	}
+	return float64(r.Cases[n-1]-r.Cases[n-1-window]) / float64(window)
+}
+
+// SortByGrowth orders records by AverageDailyGrowth over window days,
+// descending.
+func (d Dataset) SortByGrowth(window int) {
+	sort.Slice(d.Records, func(i, j int) bool {
+		return d.Records[i].AverageDailyGrowth(window) > d.Records[j].AverageDailyGrowth(window)
+	})
+}
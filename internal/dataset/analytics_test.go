@@ -0,0 +1,185 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDailyNew(t *testing.T) {
+	r := Record{Cases: []int{10, 15, 22, 20}}
+	got := r.DailyNew()
+	want := []int{10, 5, 7, -2}
+	if !equalInts(got, want) {
+		t.Errorf("DailyNew() = %v, want %v", got, want)
+	}
+}
+
+func TestSmoothedNew(t *testing.T) {
+	// DailyNew is [10, 10, 20, 30]; a 2-day trailing average of that
+	// gives [10, 10, 15, 25] (the first two days average over
+	// however many days are available).
+	r := Record{Cases: []int{10, 20, 40, 70}}
+	got := r.SmoothedNew(2)
+	want := []float64{10, 10, 15, 25}
+	if !equalFloats(got, want) {
+		t.Errorf("SmoothedNew(2) = %v, want %v", got, want)
+	}
+}
+
+func TestDoublingTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		cases  []int
+		window int
+		want   float64
+		isInf  bool
+	}{
+		{
+			name:   "window at least n is not enough history",
+			cases:  []int{10, 20, 40},
+			window: 3,
+			want:   0,
+		},
+		{
+			name:   "window greater than n is not enough history",
+			cases:  []int{10, 20, 40},
+			window: 5,
+			want:   0,
+		},
+		{
+			name:   "non-positive window is not enough history",
+			cases:  []int{10, 20, 40},
+			window: 0,
+			want:   0,
+		},
+		{
+			name:   "zero cases window-ago is flat/declining",
+			cases:  []int{0, 0, 5},
+			window: 2,
+			isInf:  true,
+		},
+		{
+			name:   "flat cases is flat/declining",
+			cases:  []int{10, 10, 10},
+			window: 2,
+			isInf:  true,
+		},
+		{
+			name:   "declining cases is flat/declining",
+			cases:  []int{20, 15, 10},
+			window: 2,
+			isInf:  true,
+		},
+		{
+			name:   "doubling over the window",
+			cases:  []int{10, 15, 20},
+			window: 2,
+			want:   2 * math.Ln2 / math.Log(2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{Cases: tt.cases}
+			got := r.DoublingTime(tt.window)
+			if tt.isInf {
+				if !math.IsInf(got, 1) {
+					t.Errorf("DoublingTime(%v) = %v, want +Inf", tt.window, got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DoublingTime(%v) = %v, want %v", tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerCapita(t *testing.T) {
+	r := Record{Cases: []int{1, 2, 3}}
+
+	if got := r.PerCapita(0); got != nil {
+		t.Errorf("PerCapita(0) = %v, want nil", got)
+	}
+	if got := r.PerCapita(-1); got != nil {
+		t.Errorf("PerCapita(-1) = %v, want nil", got)
+	}
+
+	got := r.PerCapita(1000000)
+	want := []float64{0.1, 0.2, 0.3}
+	if !approxEqualFloats(got, want) {
+		t.Errorf("PerCapita(1000000) = %v, want %v", got, want)
+	}
+}
+
+func TestAverageDailyGrowth(t *testing.T) {
+	r := Record{Cases: []int{10, 20, 40, 70}}
+
+	if got, want := r.AverageDailyGrowth(2), 25.0; got != want {
+		t.Errorf("AverageDailyGrowth(2) = %v, want %v", got, want)
+	}
+	// window >= n falls back to the full series.
+	if got, want := r.AverageDailyGrowth(10), 20.0; got != want {
+		t.Errorf("AverageDailyGrowth(10) = %v, want %v", got, want)
+	}
+	if got, want := r.AverageDailyGrowth(0), 20.0; got != want {
+		t.Errorf("AverageDailyGrowth(0) = %v, want %v", got, want)
+	}
+
+	single := Record{Cases: []int{5}}
+	if got, want := single.AverageDailyGrowth(0), 0.0; got != want {
+		t.Errorf("AverageDailyGrowth(0) on single-day record = %v, want %v", got, want)
+	}
+}
+
+func TestSortByGrowth(t *testing.T) {
+	d := Dataset{Records: []Record{
+		{Country: "Slow", Cases: []int{10, 11, 12}},
+		{Country: "Fast", Cases: []int{10, 20, 40}},
+		{Country: "Flat", Cases: []int{10, 10, 10}},
+	}}
+	d.SortByGrowth(2)
+
+	want := []string{"Fast", "Slow", "Flat"}
+	for i, name := range want {
+		if d.Records[i].Country != name {
+			t.Errorf("SortByGrowth order[%d] = %v, want %v", i, d.Records[i].Country, name)
+		}
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func approxEqualFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+// Package dataset holds the parsed CSSE time-series data and the
+// aggregation helpers used to turn it into totals, per-country
+// summaries and top-N rankings.
+package dataset
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kind identifies one of the three CSSE time series.
+type Kind int
+
+const (
+	Confirmed Kind = iota
+	Dead
+	Recovered
+)
+
+// String returns the human-readable name of the kind, e.g. "confirmed".
+func (k Kind) String() string {
+	switch k {
+	case Confirmed:
+		return "confirmed"
+	case Dead:
+		return "dead"
+	case Recovered:
+		return "recovered"
+	default:
+		return "unknown"
+	}
+}
+
+// Filename returns the CSSE CSV filename for the kind.
+func (k Kind) Filename() string {
+	switch k {
+	case Confirmed:
+		return "time_series_covid19_confirmed_global.csv"
+	case Dead:
+		return "time_series_covid19_deaths_global.csv"
+	case Recovered:
+		return "time_series_covid19_recovered_global.csv"
+	default:
+		return ""
+	}
+}
+
+// Kinds lists all known kinds in a stable order.
+var Kinds = [3]Kind{Confirmed, Dead, Recovered}
+
+// Record is a single row of a CSSE time series: a province or country
+// with its daily cumulative case counts.
+type Record struct {
+	Province string
+	Country  string
+	Lat      float32
+	Long     float32
+	Cases    []int
+}
+
+// Dataset is a parsed CSSE time series: the CSV header (dates) plus
+// one Record per row.
+type Dataset struct {
+	Header  []string
+	Records []Record
+}
+
+// Filter and Reduce are pure aggregation over already-decoded data:
+// they have no I/O and cannot fail in a way worth logging, so unlike
+// the fetch/decode pipeline in cmd/covid they take no *logging.Logger.
+
+// Filter returns the reduced (per-country) record matching country,
+// case-insensitively.
+func (d Dataset) Filter(country string) (Record, bool) {
+	d = d.Reduce()
+	for i := 0; i < len(d.Records); i++ {
+		if strings.EqualFold(d.Records[i].Country, country) {
+			return d.Records[i], true
+		}
+	}
+	return Record{}, false
+}
+
+// Reduce collapses per-province records into per-country totals.
+func (d Dataset) Reduce() Dataset {
+	d.sortCountry()
+	var rs []Record
+	c := ""
+	for i := 0; i < len(d.Records); i++ {
+		if d.Records[i].Country != c {
+			rs = append(rs, d.Records[i])
+			c = d.Records[i].Country
+		} else {
+			l := len(rs) - 1
+			for j := 0; j < len(d.Records[i].Cases); j++ {
+				rs[l].Cases[j] += d.Records[i].Cases[j]
+			}
+		}
+	}
+	return Dataset{Header: d.Header, Records: rs}
+}
+
+// Sum returns the total cases across all records on day i. Negative i
+// counts back from the last day, as with slice indexing.
+func (d Dataset) Sum(i int) int {
+	if i < 0 {
+		i = len(d.Records[0].Cases) + i
+	}
+	s := 0
+	for _, r := range d.Records {
+		s += r.Cases[i]
+	}
+	return s
+}
+
+// Sort orders records by the last day's case count, descending.
+func (d Dataset) Sort() {
+	sort.Slice(d.Records, func(i, j int) bool {
+		return d.Records[i].Cases[len(d.Records[0].Cases)-1] > d.Records[j].Cases[len(d.Records[0].Cases)-1]
+	})
+}
+
+func (d Dataset) sortCountry() {
+	sort.Slice(d.Records, func(i, j int) bool {
+		return d.Records[i].Country < d.Records[j].Country
+	})
+}
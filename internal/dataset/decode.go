@@ -0,0 +1,60 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Decode parses raw CSSE CSV bytes (province, country, lat, long,
+// followed by one cumulative-count column per day) into a Dataset.
+func Decode(raw []byte) (Dataset, error) {
+	r := csv.NewReader(strings.NewReader(string(raw)))
+	rr, err := r.ReadAll()
+	if err != nil {
+		return Dataset{}, fmt.Errorf("cannot read csv data: %w", err)
+	}
+	if len(rr) == 0 {
+		return Dataset{}, fmt.Errorf("empty csv data")
+	}
+
+	var recs []Record
+	for i := 1; i < len(rr); i++ {
+		var cases []int
+		for j := 4; j < len(rr[0]); j++ {
+			n, err := strconv.Atoi(rr[i][j])
+			if err != nil {
+				return Dataset{}, fmt.Errorf("cannot convert number: %w", err)
+			}
+			cases = append(cases, n)
+		}
+
+		lat, err := atof(rr[i][2])
+		if err != nil {
+			return Dataset{}, fmt.Errorf("cannot convert number: %w", err)
+		}
+		long, err := atof(rr[i][3])
+		if err != nil {
+			return Dataset{}, fmt.Errorf("cannot convert number: %w", err)
+		}
+
+		recs = append(recs, Record{
+			Province: rr[i][0],
+			Country:  rr[i][1],
+			Lat:      lat,
+			Long:     long,
+			Cases:    cases,
+		})
+	}
+
+	return Dataset{Header: rr[0], Records: recs}, nil
+}
+
+func atof(s string) (float32, error) {
+	n, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(n), nil
+}
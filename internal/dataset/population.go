@@ -0,0 +1,85 @@
+package dataset
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed population.csv
+var embeddedPopulationCSV []byte
+
+// Population maps a country name to its population, looked up
+// case-insensitively the same way as Dataset.Filter.
+type Population map[string]int
+
+// Lookup returns the population for country, matched
+// case-insensitively.
+func (p Population) Lookup(country string) (int, bool) {
+	for name, n := range p {
+		if strings.EqualFold(name, country) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// DefaultPopulation returns the population table embedded in the
+// binary.
+func DefaultPopulation() (Population, error) {
+	return parsePopulationCSV(embeddedPopulationCSV)
+}
+
+// LoadPopulation returns the embedded population table, overlaid with
+// entries from path if it exists. A missing path is not an error; it
+// just means only the embedded table is used.
+func LoadPopulation(path string) (Population, error) {
+	pop, err := DefaultPopulation()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pop, nil
+		}
+		return nil, fmt.Errorf("cannot read population file: %w", err)
+	}
+
+	overrides, err := parsePopulationCSV(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse population file %v: %w", path, err)
+	}
+	for name, n := range overrides {
+		pop[name] = n
+	}
+	return pop, nil
+}
+
+func parsePopulationCSV(raw []byte) (Population, error) {
+	r := csv.NewReader(strings.NewReader(string(raw)))
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read population csv: %w", err)
+	}
+
+	pop := make(Population, len(rows))
+	for i, row := range rows {
+		if i == 0 && len(row) > 0 && strings.EqualFold(row[0], "country") {
+			continue
+		}
+		if len(row) < 2 {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(row[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid population for %v: %w", row[0], err)
+		}
+		pop[row[0]] = n
+	}
+	return pop, nil
+}
@@ -0,0 +1,74 @@
+package dataset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultPopulation(t *testing.T) {
+	pop, err := DefaultPopulation()
+	if err != nil {
+		t.Fatalf("DefaultPopulation: %v", err)
+	}
+
+	n, found := pop.Lookup("us")
+	if !found {
+		t.Fatal("Lookup(\"us\") not found")
+	}
+	if n != 331002651 {
+		t.Errorf("Lookup(\"us\") = %v, want 331002651", n)
+	}
+
+	if _, found := pop.Lookup("Nowhereistan"); found {
+		t.Error("Lookup(\"Nowhereistan\") found=true, want false")
+	}
+}
+
+func TestLoadPopulationOverride(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "population.csv")
+	// Overrides the embedded US figure and adds a country missing from
+	// the embedded table.
+	csv := "country,population\nUS,1\nTestlandia,42\n"
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pop, err := LoadPopulation(path)
+	if err != nil {
+		t.Fatalf("LoadPopulation: %v", err)
+	}
+
+	if n, _ := pop.Lookup("US"); n != 1 {
+		t.Errorf("Lookup(\"US\") = %v, want 1 (overridden)", n)
+	}
+	if n, _ := pop.Lookup("Testlandia"); n != 42 {
+		t.Errorf("Lookup(\"Testlandia\") = %v, want 42 (added)", n)
+	}
+	if n, found := pop.Lookup("Brazil"); !found || n != 212559417 {
+		t.Errorf("Lookup(\"Brazil\") = %v, %v, want 212559417, true (untouched)", n, found)
+	}
+}
+
+func TestLoadPopulationMissingFile(t *testing.T) {
+	pop, err := LoadPopulation(filepath.Join(t.TempDir(), "does-not-exist.csv"))
+	if err != nil {
+		t.Fatalf("LoadPopulation with missing file: %v", err)
+	}
+	if n, found := pop.Lookup("US"); !found || n != 331002651 {
+		t.Errorf("Lookup(\"US\") = %v, %v, want 331002651, true (falls back to embedded)", n, found)
+	}
+}
+
+func TestLoadPopulationInvalidValue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "population.csv")
+	if err := os.WriteFile(path, []byte("country,population\nTestlandia,not-a-number\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadPopulation(path); err == nil {
+		t.Error("LoadPopulation with invalid population value: want error, got nil")
+	}
+}
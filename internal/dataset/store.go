@@ -0,0 +1,134 @@
+package dataset
+
+import (
+	"sync"
+	"time"
+)
+
+// Global is the worldwide total (and latest day's new cases) for one
+// kind of case count.
+type Global struct {
+	Confirmed    int
+	NewConfirmed int
+	Dead         int
+	NewDead      int
+	Recovered    int
+	NewRecovered int
+}
+
+// CountrySummary is the per-country total (and latest day's new
+// cases) for one kind of case count.
+type CountrySummary struct {
+	Country   string
+	Confirmed int
+	Dead      int
+	Recovered int
+}
+
+// Store holds the latest decoded Dataset for each Kind and serves the
+// read side of the fetch -> decode -> aggregate pipeline. It is safe
+// for concurrent use: Update is called by the periodic refresh loop
+// while HTTP handlers call the read methods.
+type Store struct {
+	mu      sync.RWMutex
+	sets    [3]Dataset
+	updated time.Time
+}
+
+// NewStore returns an empty Store. It holds no data until Update is
+// called.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Update replaces the datasets held by the store and records the
+// refresh time.
+func (s *Store) Update(confirmed, dead, recovered Dataset, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sets[Confirmed] = confirmed
+	s.sets[Dead] = dead
+	s.sets[Recovered] = recovered
+	s.updated = at
+}
+
+// LastUpdated returns the time of the most recent successful Update,
+// or the zero time if the store has never been populated.
+func (s *Store) LastUpdated() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.updated
+}
+
+// Global returns the worldwide totals across all three kinds.
+func (s *Store) Global() Global {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var g Global
+	g.Confirmed = s.sets[Confirmed].Sum(-1)
+	g.NewConfirmed = g.Confirmed - s.sets[Confirmed].Sum(-2)
+	g.Dead = s.sets[Dead].Sum(-1)
+	g.NewDead = g.Dead - s.sets[Dead].Sum(-2)
+	g.Recovered = s.sets[Recovered].Sum(-1)
+	g.NewRecovered = g.Recovered - s.sets[Recovered].Sum(-2)
+	return g
+}
+
+// Country returns the reduced per-country totals for name, matched
+// case-insensitively the same way as Dataset.Filter. It reports
+// found=false if name is missing from any of the three series, since
+// the CSSE confirmed, deaths and recovered series are not guaranteed
+// to carry identical country sets.
+func (s *Store) Country(name string) (CountrySummary, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conf, found := s.sets[Confirmed].Filter(name)
+	if !found {
+		return CountrySummary{}, false
+	}
+	dd, found := s.sets[Dead].Filter(name)
+	if !found {
+		return CountrySummary{}, false
+	}
+	recov, found := s.sets[Recovered].Filter(name)
+	if !found {
+		return CountrySummary{}, false
+	}
+
+	return CountrySummary{
+		Country:   conf.Country,
+		Confirmed: conf.Cases[len(conf.Cases)-1],
+		Dead:      dd.Cases[len(dd.Cases)-1],
+		Recovered: recov.Cases[len(recov.Cases)-1],
+	}, true
+}
+
+// Top returns the n countries with the highest case count of the
+// given kind, descending. It may return fewer than n if the dataset
+// has fewer countries.
+func (s *Store) Top(kind Kind, n int) []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := s.sets[kind].Reduce()
+	r.Sort()
+	if n > len(r.Records) {
+		n = len(r.Records)
+	}
+	return r.Records[:n]
+}
+
+// Series returns the reduced daily cumulative case series for
+// country and kind.
+func (s *Store) Series(country string, kind Kind) ([]int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, found := s.sets[kind].Filter(country)
+	if !found {
+		return nil, false
+	}
+	return rec.Cases, true
+}
@@ -0,0 +1,123 @@
+package dataset
+
+import (
+	"testing"
+	"time"
+)
+
+func testSets() (confirmed, dead, recovered Dataset) {
+	confirmed = Dataset{Records: []Record{
+		{Country: "Testlandia", Cases: []int{10, 20, 30}},
+		{Country: "Onlyconfirmedia", Cases: []int{1, 2, 3}},
+	}}
+	dead = Dataset{Records: []Record{
+		{Country: "Testlandia", Cases: []int{1, 2, 3}},
+	}}
+	recovered = Dataset{Records: []Record{
+		{Country: "Testlandia", Cases: []int{5, 10, 15}},
+	}}
+	return
+}
+
+func TestStoreLastUpdated(t *testing.T) {
+	s := NewStore()
+	if got := s.LastUpdated(); !got.IsZero() {
+		t.Errorf("LastUpdated() on empty store = %v, want zero time", got)
+	}
+
+	confirmed, dead, recovered := testSets()
+	at := time.Now()
+	s.Update(confirmed, dead, recovered, at)
+	if got := s.LastUpdated(); !got.Equal(at) {
+		t.Errorf("LastUpdated() = %v, want %v", got, at)
+	}
+}
+
+func TestStoreGlobal(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	got := s.Global()
+	want := Global{
+		Confirmed:    33,
+		NewConfirmed: 11,
+		Dead:         3,
+		NewDead:      1,
+		Recovered:    15,
+		NewRecovered: 5,
+	}
+	if got != want {
+		t.Errorf("Global() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStoreCountry(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	got, found := s.Country("testlandia")
+	if !found {
+		t.Fatal("Country(\"testlandia\") not found")
+	}
+	want := CountrySummary{Country: "Testlandia", Confirmed: 30, Dead: 3, Recovered: 15}
+	if got != want {
+		t.Errorf("Country(\"testlandia\") = %+v, want %+v", got, want)
+	}
+}
+
+func TestStoreCountryPartialSeries(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	if _, found := s.Country("Onlyconfirmedia"); found {
+		t.Error("Country(\"Onlyconfirmedia\") found=true, want false: country is missing from dead/recovered")
+	}
+}
+
+func TestStoreCountryNotFound(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	if _, found := s.Country("Nowhereistan"); found {
+		t.Error("Country(\"Nowhereistan\") found=true, want false")
+	}
+}
+
+func TestStoreTop(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	top := s.Top(Confirmed, 1)
+	if len(top) != 1 || top[0].Country != "Testlandia" {
+		t.Errorf("Top(Confirmed, 1) = %+v, want [Testlandia]", top)
+	}
+
+	top = s.Top(Confirmed, 10)
+	if len(top) != 2 {
+		t.Errorf("Top(Confirmed, 10) len = %v, want 2 (clamped to dataset size)", len(top))
+	}
+}
+
+func TestStoreSeries(t *testing.T) {
+	s := NewStore()
+	confirmed, dead, recovered := testSets()
+	s.Update(confirmed, dead, recovered, time.Now())
+
+	series, found := s.Series("Testlandia", Confirmed)
+	if !found {
+		t.Fatal("Series(\"Testlandia\", Confirmed) not found")
+	}
+	want := []int{10, 20, 30}
+	if !equalInts(series, want) {
+		t.Errorf("Series(\"Testlandia\", Confirmed) = %v, want %v", series, want)
+	}
+
+	if _, found := s.Series("Nowhereistan", Confirmed); found {
+		t.Error("Series(\"Nowhereistan\", Confirmed) found=true, want false")
+	}
+}
@@ -0,0 +1,69 @@
+// Package logging provides the small structured logger used across
+// the covid subsystems.
+package logging
+
+import (
+	"log/slog"
+	"os"
+)
+
+// Logger is a thin wrapper around log/slog so call sites can log
+// leveled, field-tagged messages without depending on the slog API
+// directly.
+type Logger struct {
+	l *slog.Logger
+}
+
+// New builds a Logger that writes to stderr at the given level and
+// format. level must be one of debug, info, warn or error; format
+// must be text or json. Unknown values fall back to info/text.
+func New(level, format string) *Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	var h slog.Handler
+	if format == "json" {
+		h = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		h = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	return &Logger{l: slog.New(h)}
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func (lg *Logger) Debug(msg string, args ...any) { lg.l.Debug(msg, args...) }
+func (lg *Logger) Info(msg string, args ...any)  { lg.l.Info(msg, args...) }
+func (lg *Logger) Warn(msg string, args ...any)  { lg.l.Warn(msg, args...) }
+func (lg *Logger) Error(msg string, args ...any) { lg.l.Error(msg, args...) }
+
+// ValidLevel reports whether level is one of the accepted
+// --log-level values.
+func ValidLevel(level string) bool {
+	switch level {
+	case "debug", "info", "warn", "error":
+		return true
+	}
+	return false
+}
+
+// ValidFormat reports whether format is one of the accepted
+// --log-format values.
+func ValidFormat(format string) bool {
+	switch format {
+	case "text", "json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Package csse fetches the raw CSSE COVID-19 time-series CSVs from
+// github.com/CSSEGISandData/COVID-19, either through the GitHub
+// Contents API or directly from raw.githubusercontent.com.
+package csse
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v30/github"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+const remoteServerTimeout = 10 * time.Second
+
+// GithubContentsSource fetches CSSE time series through the GitHub
+// Contents API. It is subject to the API's base64 encoding overhead
+// and the Contents API's 1MB file size cap.
+type GithubContentsSource struct {
+	client *github.Client
+}
+
+// NewGithubContentsSource builds a GithubContentsSource using c for
+// transport, or the default http.Client if c is nil.
+func NewGithubContentsSource(c *http.Client) *GithubContentsSource {
+	return &GithubContentsSource{client: github.NewClient(c)}
+}
+
+// Fetch retrieves the CSSE time-series CSV for kind and returns its
+// raw bytes, decoded from the Contents API's base64 payload.
+func (s *GithubContentsSource) Fetch(ctx context.Context, kind dataset.Kind) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteServerTimeout)
+	defer cancel()
+
+	repoContent, _, _, err := s.client.Repositories.GetContents(ctx, "CSSEGISandData", "COVID-19",
+		fmt.Sprintf("csse_covid_19_data/csse_covid_19_time_series/%s", kind.Filename()), nil)
+	if err != nil {
+		if _, ok := err.(*github.RateLimitError); ok {
+			return nil, fmt.Errorf("hit rate limit: %w", err)
+		}
+		return nil, fmt.Errorf("cannot get data: %w", err)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(*repoContent.Content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode data: %w", err)
+	}
+	return content, nil
+}
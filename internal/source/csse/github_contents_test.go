@@ -0,0 +1,62 @@
+package csse
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+func TestGithubContentsSourceFetch(t *testing.T) {
+	want := []byte("Province/State,Country/Region\n")
+	wantPath := fmt.Sprintf("/repos/CSSEGISandData/COVID-19/contents/csse_covid_19_data/csse_covid_19_time_series/%s",
+		dataset.Confirmed.Filename())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != wantPath {
+			http.NotFound(rw, req)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(rw, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString(want))
+	}))
+	defer srv.Close()
+
+	s := NewGithubContentsSource(nil)
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.client.BaseURL = baseURL
+
+	got, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Fetch = %q, want %q", got, want)
+	}
+}
+
+func TestGithubContentsSourceFetchErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "nope", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := NewGithubContentsSource(nil)
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.client.BaseURL = baseURL
+
+	if _, err := s.Fetch(context.Background(), dataset.Confirmed); err == nil {
+		t.Error("Fetch with 404 response: want error, got nil")
+	}
+}
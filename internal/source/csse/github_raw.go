@@ -0,0 +1,95 @@
+package csse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+const rawBaseURL = "https://raw.githubusercontent.com/CSSEGISandData/COVID-19/master/csse_covid_19_data/csse_covid_19_time_series"
+
+// RawGithubSource fetches CSSE time series directly from
+// raw.githubusercontent.com, avoiding the Contents API's base64
+// overhead and 1MB file size cap. It remembers the ETag/Last-Modified
+// of the last successful fetch per kind and sends them as
+// If-None-Match/If-Modified-Since on the next Fetch, so an unchanged
+// file costs a 304 instead of a full re-download.
+type RawGithubSource struct {
+	client  *http.Client
+	baseURL string
+
+	mu    sync.Mutex
+	cache map[dataset.Kind]cachedFetch
+}
+
+type cachedFetch struct {
+	etag         string
+	lastModified string
+	content      []byte
+}
+
+// NewRawGithubSource builds a RawGithubSource using c for transport,
+// or http.DefaultClient if c is nil.
+func NewRawGithubSource(c *http.Client) *RawGithubSource {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &RawGithubSource{client: c, baseURL: rawBaseURL, cache: make(map[dataset.Kind]cachedFetch)}
+}
+
+// Fetch retrieves the CSSE time-series CSV for kind, reusing the
+// previous fetch's content if the server reports it as unchanged.
+func (s *RawGithubSource) Fetch(ctx context.Context, kind dataset.Kind) ([]byte, error) {
+	url := s.baseURL + "/" + kind.Filename()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request: %w", err)
+	}
+
+	s.mu.Lock()
+	prev, cached := s.cache[kind]
+	s.mu.Unlock()
+	if cached {
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if !cached {
+			return nil, fmt.Errorf("cannot get data: got 304 with no prior cached response")
+		}
+		return prev.content, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot get data: unexpected status %v", resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read data: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cache[kind] = cachedFetch{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		content:      content,
+	}
+	s.mu.Unlock()
+
+	return content, nil
+}
@@ -0,0 +1,84 @@
+package csse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+func TestRawGithubSourceFetch(t *testing.T) {
+	want := []byte("Province/State,Country/Region\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+dataset.Confirmed.Filename() {
+			http.NotFound(rw, req)
+			return
+		}
+		_, _ = rw.Write(want)
+	}))
+	defer srv.Close()
+
+	s := NewRawGithubSource(nil)
+	s.baseURL = srv.URL
+
+	got, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Fetch = %q, want %q", got, want)
+	}
+}
+
+func TestRawGithubSourceFetchErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "nope", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := NewRawGithubSource(nil)
+	s.baseURL = srv.URL
+
+	if _, err := s.Fetch(context.Background(), dataset.Confirmed); err == nil {
+		t.Error("Fetch with 404 response: want error, got nil")
+	}
+}
+
+func TestRawGithubSourceFetchConditionalRequest(t *testing.T) {
+	want := []byte("Province/State,Country/Region\n")
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write(want)
+	}))
+	defer srv.Close()
+
+	s := NewRawGithubSource(nil)
+	s.baseURL = srv.URL
+
+	first, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("first Fetch: %v", err)
+	}
+	if string(first) != string(want) {
+		t.Errorf("first Fetch = %q, want %q", first, want)
+	}
+
+	second, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("second Fetch: %v", err)
+	}
+	if string(second) != string(want) {
+		t.Errorf("second Fetch (304) = %q, want cached %q", second, want)
+	}
+	if requests != 2 {
+		t.Errorf("server saw %v requests, want 2", requests)
+	}
+}
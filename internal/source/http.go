@@ -0,0 +1,54 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+// HTTPSource fetches CSSE-shaped CSVs from an arbitrary base URL, so
+// users can point the tool at a mirror or their own aggregation
+// instead of github.com/CSSEGISandData/COVID-19.
+type HTTPSource struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewHTTPSource builds an HTTPSource that fetches
+// "<baseURL>/<kind filename>" using c for transport, or
+// http.DefaultClient if c is nil.
+func NewHTTPSource(c *http.Client, baseURL string) *HTTPSource {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &HTTPSource{client: c, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Fetch retrieves the CSV for kind from baseURL.
+func (s *HTTPSource) Fetch(ctx context.Context, kind dataset.Kind) ([]byte, error) {
+	url := s.baseURL + "/" + kind.Filename()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot get data: unexpected status %v", resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read data: %w", err)
+	}
+	return content, nil
+}
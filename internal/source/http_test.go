@@ -0,0 +1,61 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+func TestHTTPSourceFetch(t *testing.T) {
+	want := []byte("Province/State,Country/Region\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+dataset.Confirmed.Filename() {
+			http.NotFound(rw, req)
+			return
+		}
+		_, _ = rw.Write(want)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPSource(nil, srv.URL)
+	got, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Fetch = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPSourceFetchTrimsTrailingSlash(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, "path=%s", req.URL.Path)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPSource(nil, srv.URL+"/")
+	got, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	want := "path=/" + dataset.Confirmed.Filename()
+	if string(got) != want {
+		t.Errorf("Fetch = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPSourceFetchErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "nope", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPSource(nil, srv.URL)
+	if _, err := s.Fetch(context.Background(), dataset.Confirmed); err == nil {
+		t.Error("Fetch with 404 response: want error, got nil")
+	}
+}
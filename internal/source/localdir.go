@@ -0,0 +1,35 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+// LocalDirSource reads previously-saved CSSE CSVs from a local
+// directory, e.g. ~/covid. It never touches the network, which makes
+// it the basis of --offline.
+type LocalDirSource struct {
+	dir string
+}
+
+// NewLocalDirSource builds a LocalDirSource reading CSVs out of dir.
+func NewLocalDirSource(dir string) *LocalDirSource {
+	return &LocalDirSource{dir: dir}
+}
+
+// Fetch reads the CSV for kind from dir. It fails if the file isn't
+// there, rather than falling back to the network.
+func (s *LocalDirSource) Fetch(ctx context.Context, kind dataset.Kind) ([]byte, error) {
+	if s.dir == "" {
+		return nil, fmt.Errorf("no local data directory configured")
+	}
+	content, err := os.ReadFile(filepath.Join(s.dir, kind.Filename()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cached data: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,41 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+func TestLocalDirSourceFetch(t *testing.T) {
+	dir := t.TempDir()
+	want := []byte("Province/State,Country/Region\n")
+	if err := os.WriteFile(filepath.Join(dir, dataset.Confirmed.Filename()), want, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewLocalDirSource(dir)
+	got, err := s.Fetch(context.Background(), dataset.Confirmed)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Fetch = %q, want %q", got, want)
+	}
+}
+
+func TestLocalDirSourceFetchMissingFile(t *testing.T) {
+	s := NewLocalDirSource(t.TempDir())
+	if _, err := s.Fetch(context.Background(), dataset.Confirmed); err == nil {
+		t.Error("Fetch with no cached file: want error, got nil")
+	}
+}
+
+func TestLocalDirSourceFetchNoDir(t *testing.T) {
+	s := NewLocalDirSource("")
+	if _, err := s.Fetch(context.Background(), dataset.Confirmed); err == nil {
+		t.Error("Fetch with no configured dir: want error, got nil")
+	}
+}
@@ -0,0 +1,16 @@
+// Package source defines the pluggable data-source abstraction used
+// to retrieve the raw CSSE CSVs, plus the generic (non-CSSE-specific)
+// implementations. See the csse subpackage for sources that talk to
+// github.com/CSSEGISandData/COVID-19 specifically.
+package source
+
+import (
+	"context"
+
+	"github.com/m4ns0ur/covid/internal/dataset"
+)
+
+// Source fetches the raw CSV bytes for one CSSE time-series kind.
+type Source interface {
+	Fetch(ctx context.Context, kind dataset.Kind) ([]byte, error)
+}